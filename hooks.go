@@ -0,0 +1,97 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// BeforeHook runs before a lifecycle step (Begin/Commit/Rollback) and
+// returns the ctx to use for the rest of the call - returning nil keeps
+// ctx unchanged. This exists so instrumentation (see uow/otel) can
+// attach a span to the context later hooks observe; the action itself
+// is invoked with the UOW only, so a ctx attached here does not reach it.
+type BeforeHook func(ctx context.Context, options *sql.TxOptions, attempt int) context.Context
+
+// AfterHook runs after a lifecycle step completes, with its outcome.
+// AfterBegin's duration covers Begin alone; AfterCommit's and
+// AfterRollback's duration covers the whole transaction, from Begin
+// through that call, matching what a "transaction duration" metric
+// should report.
+type AfterHook func(ctx context.Context, options *sql.TxOptions, attempt int, duration time.Duration, err error)
+
+// PanicHook runs when the action panics, before the transaction is
+// rolled back and before the panic is re-raised, so it can end a span
+// opened by BeforeBegin with the panic recorded on it; AfterRollback
+// then fires against an already-ended span and is a no-op.
+type PanicHook func(ctx context.Context, options *sql.TxOptions, attempt int, recovered any)
+
+// Hooks are lifecycle callbacks RunTxWithResult invokes around Begin,
+// Commit and Rollback. A nil field is simply skipped. Attach a default
+// set to a Factory (stdsql.Factory and pgxv5.Factory both expose a
+// Hooks field) or pass per-call overrides via WithHooks.
+type Hooks struct {
+	BeforeBegin    BeforeHook
+	AfterBegin     AfterHook
+	BeforeCommit   BeforeHook
+	AfterCommit    AfterHook
+	BeforeRollback BeforeHook
+	AfterRollback  AfterHook
+	OnPanic        PanicHook
+}
+
+// merge returns h with every non-nil field of override applied on top.
+func (h Hooks) merge(override Hooks) Hooks {
+	if override.BeforeBegin != nil {
+		h.BeforeBegin = override.BeforeBegin
+	}
+	if override.AfterBegin != nil {
+		h.AfterBegin = override.AfterBegin
+	}
+	if override.BeforeCommit != nil {
+		h.BeforeCommit = override.BeforeCommit
+	}
+	if override.AfterCommit != nil {
+		h.AfterCommit = override.AfterCommit
+	}
+	if override.BeforeRollback != nil {
+		h.BeforeRollback = override.BeforeRollback
+	}
+	if override.AfterRollback != nil {
+		h.AfterRollback = override.AfterRollback
+	}
+	if override.OnPanic != nil {
+		h.OnPanic = override.OnPanic
+	}
+	return h
+}
+
+// hooksProvider is implemented by a Factory that carries default Hooks,
+// e.g. stdsql.Factory and pgxv5.Factory.
+type hooksProvider interface {
+	GetHooks() Hooks
+}
+
+// Option configures a single RunTx* call.
+type Option func(*txConfig)
+
+type txConfig struct {
+	hooks   Hooks
+	attempt int
+}
+
+// WithHooks attaches lifecycle hooks for one call, merged field-by-field
+// over any Hooks the Factory already carries.
+func WithHooks(hooks Hooks) Option {
+	return func(c *txConfig) {
+		c.hooks = c.hooks.merge(hooks)
+	}
+}
+
+// WithAttempt records the attempt number hooks observe. RunTxWithRetry
+// and RunTxWithResultAndRetry set this automatically; it defaults to 1.
+func WithAttempt(attempt int) Option {
+	return func(c *txConfig) {
+		c.attempt = attempt
+	}
+}
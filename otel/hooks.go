@@ -0,0 +1,169 @@
+package otel
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/callmemars1/go-uow"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/callmemars1/go-uow/otel"
+
+type spanCtxKey struct{}
+
+// spanState is stored in the context as a pointer so onPanic - which
+// runs before the rollback it triggers - can flag that it already
+// recorded this transaction's outcome, letting afterOutcome's later
+// "rollback" call see the flag through the same context value.
+type spanState struct {
+	span     trace.Span
+	panicked bool
+}
+
+// Config selects the tracer/meter Hooks uses. A zero Config falls back
+// to the global TracerProvider/MeterProvider.
+type Config struct {
+	Tracer trace.Tracer
+	Meter  metric.Meter
+}
+
+// Hooks returns uow.Hooks that wrap every transaction in a span tagged
+// with its isolation level and read-only flag, set the span status from
+// the final error, and record a db.transaction.duration histogram plus
+// a db.transaction.outcome counter. A retried transaction shows up as
+// one outcome event per attempt, each carrying its own
+// db.transaction.attempt attribute: an attempt whose action returned an
+// error is a rollback-outcome event, and an attempt whose Commit failed
+// is a commit-outcome event, the same outcome a successful commit gets.
+func Hooks(cfg Config) uow.Hooks {
+	tracer := cfg.Tracer
+	if tracer == nil {
+		tracer = otel.Tracer(instrumentationName)
+	}
+	meter := cfg.Meter
+	if meter == nil {
+		meter = otel.Meter(instrumentationName)
+	}
+
+	duration, _ := meter.Float64Histogram(
+		"db.transaction.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of a go-uow transaction, from Begin to Commit or Rollback."),
+	)
+	outcomes, _ := meter.Int64Counter(
+		"db.transaction.outcome",
+		metric.WithDescription("Count of go-uow transaction outcomes, by result."),
+	)
+
+	h := &hooks{tracer: tracer, duration: duration, outcomes: outcomes}
+
+	return uow.Hooks{
+		BeforeBegin:   h.beforeBegin,
+		AfterCommit:   h.afterOutcome("commit"),
+		AfterRollback: h.afterOutcome("rollback"),
+		OnPanic:       h.onPanic,
+	}
+}
+
+type hooks struct {
+	tracer trace.Tracer
+
+	duration metric.Float64Histogram
+	outcomes metric.Int64Counter
+}
+
+func (h *hooks) beforeBegin(ctx context.Context, options *sql.TxOptions, attempt int) context.Context {
+	ctx, span := h.tracer.Start(ctx, "uow.transaction")
+
+	attrs := []attribute.KeyValue{
+		attribute.Int("db.transaction.attempt", attempt),
+	}
+	if options != nil {
+		attrs = append(attrs,
+			attribute.String("db.transaction.isolation_level", isolationLevelName(options.Isolation)),
+			attribute.Bool("db.transaction.read_only", options.ReadOnly),
+		)
+	}
+	span.SetAttributes(attrs...)
+
+	return context.WithValue(ctx, spanCtxKey{}, &spanState{span: span})
+}
+
+func (h *hooks) afterOutcome(outcome string) uow.AfterHook {
+	return func(ctx context.Context, options *sql.TxOptions, attempt int, duration time.Duration, err error) {
+		state, ok := spanStateFromContext(ctx)
+		if ok && state.panicked {
+			// onPanic already recorded this transaction's outcome and
+			// ended its span; this call is just the mechanical rollback
+			// a panic triggers, not a second, independent outcome.
+			return
+		}
+
+		if ok {
+			if err != nil {
+				state.span.RecordError(err)
+				state.span.SetStatus(codes.Error, err.Error())
+			} else {
+				state.span.SetStatus(codes.Ok, "")
+			}
+			state.span.End()
+		}
+
+		attrs := metric.WithAttributes(
+			attribute.String("db.transaction.outcome", outcome),
+			attribute.Int("db.transaction.attempt", attempt),
+		)
+		h.duration.Record(ctx, duration.Seconds(), attrs)
+		h.outcomes.Add(ctx, 1, attrs)
+	}
+}
+
+func (h *hooks) onPanic(ctx context.Context, options *sql.TxOptions, attempt int, recovered any) {
+	if state, ok := spanStateFromContext(ctx); ok {
+		state.panicked = true
+		state.span.RecordError(fmt.Errorf("panic: %v", recovered))
+		state.span.SetStatus(codes.Error, "panic")
+		state.span.End()
+	}
+
+	h.outcomes.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("db.transaction.outcome", "panic"),
+		attribute.Int("db.transaction.attempt", attempt),
+	))
+}
+
+func spanStateFromContext(ctx context.Context) (*spanState, bool) {
+	state, ok := ctx.Value(spanCtxKey{}).(*spanState)
+	return state, ok
+}
+
+func isolationLevelName(level sql.IsolationLevel) string {
+	switch level {
+	case sql.LevelDefault:
+		return "default"
+	case sql.LevelReadUncommitted:
+		return "read_uncommitted"
+	case sql.LevelReadCommitted:
+		return "read_committed"
+	case sql.LevelWriteCommitted:
+		return "write_committed"
+	case sql.LevelRepeatableRead:
+		return "repeatable_read"
+	case sql.LevelSnapshot:
+		return "snapshot"
+	case sql.LevelSerializable:
+		return "serializable"
+	case sql.LevelLinearizable:
+		return "linearizable"
+	default:
+		return "unknown"
+	}
+}
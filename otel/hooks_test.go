@@ -0,0 +1,93 @@
+package otel
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/callmemars1/go-uow"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type fakeUOW struct{}
+
+func (fakeUOW) MustRepoRegistry() any { return nil }
+
+func (fakeUOW) Begin(ctx context.Context, options *sql.TxOptions) error { return nil }
+
+func (fakeUOW) BeginNested(ctx context.Context) (uow.UOW[any], error) {
+	return fakeUOW{}, nil
+}
+
+func (fakeUOW) Commit(ctx context.Context) error { return nil }
+
+func (fakeUOW) Rollback(ctx context.Context) error { return nil }
+
+type fakeFactory struct {
+	hooks uow.Hooks
+}
+
+func (f fakeFactory) NewUOW(ctx context.Context) (uow.UOW[any], error) { return fakeUOW{}, nil }
+func (f fakeFactory) Release() error                                   { return nil }
+func (f fakeFactory) GetHooks() uow.Hooks                              { return f.hooks }
+
+// TestHooks_Panic_RecordsExactlyOneOutcome guards against a panicking
+// transaction recording both a "panic" outcome from OnPanic and a
+// "rollback" outcome from AfterRollback for the same event, and against
+// its span ending up with status Ok instead of Error.
+func TestHooks_Panic_RecordsExactlyOneOutcome(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	hooks := Hooks(Config{Tracer: tp.Tracer("test"), Meter: mp.Meter("test")})
+	factory := fakeFactory{hooks: hooks}
+
+	func() {
+		defer func() { _ = recover() }()
+		_ = uow.RunTx[any](context.Background(), factory, func(uow.UOW[any]) error {
+			panic("boom")
+		}, uow.DefaultTxOptions())
+	}()
+
+	ended := recorder.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d ended spans, want 1", len(ended))
+	}
+	if ended[0].Status().Code.String() != "Error" {
+		t.Errorf("span status = %v, want Error", ended[0].Status().Code)
+	}
+	if len(ended[0].Events()) == 0 {
+		t.Error("expected the panic to be recorded as a span event, got none")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	outcomeCount := 0
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "db.transaction.outcome" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("unexpected data type %T for db.transaction.outcome", m.Data)
+			}
+			for _, dp := range sum.DataPoints {
+				outcomeCount += int(dp.Value)
+			}
+		}
+	}
+
+	if outcomeCount != 1 {
+		t.Errorf("db.transaction.outcome total = %d, want 1 (one panic event, not also a rollback event)", outcomeCount)
+	}
+}
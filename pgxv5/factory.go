@@ -17,6 +17,11 @@ type Factory[TRepoRegistry any] struct {
 	pool *pgxpool.Pool
 
 	repoRegistryFactory RepoRegistryFactory[TRepoRegistry]
+
+	// Hooks, when set, are used as the default uow.Hooks for every
+	// RunTxWithResult, RunTx and retry-helper call made with this
+	// Factory. RunTxCtx does not invoke hooks.
+	Hooks uow.Hooks
 }
 
 func NewFactory[TRepoRegistry any](
@@ -44,3 +49,7 @@ func (f *Factory[TRepoRegistry]) Release() error {
 	f.pool.Close()
 	return nil
 }
+
+func (f *Factory[TRepoRegistry]) GetHooks() uow.Hooks {
+	return f.Hooks
+}
@@ -0,0 +1,128 @@
+package pgxv5
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/callmemars1/go-uow"
+	"github.com/callmemars1/go-uow/saga"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Txer is implemented by a pgxv5 UOW, letting code outside this package
+// reach the pgx.Tx backing it. SagaStore uses it to write its
+// bookkeeping row through the same transaction a step just ran in.
+type Txer interface {
+	Tx() pgx.Tx
+}
+
+var _ Txer = &pgUOW[any]{}
+
+// SagaStore is a Postgres-backed saga.SagaStore. It writes one row per
+// (saga, step) to a uow_sagas table:
+//
+//	CREATE TABLE uow_sagas (
+//		saga_id    text NOT NULL,
+//		step_index int  NOT NULL,
+//		step_name  text NOT NULL,
+//		status     text NOT NULL,
+//		PRIMARY KEY (saga_id, step_index)
+//	);
+//
+// Start and SetStepStatus write through the pgx.Tx of the UOW they're
+// given, so the bookkeeping commits or rolls back atomically with the
+// step's own effects; that UOW must come from a pgxv5.Factory. InFlight
+// reads across all sagas directly through pool, since no single step's
+// transaction spans every saga.
+type SagaStore[TRepoRegistry any] struct {
+	pool *pgxpool.Pool
+}
+
+// NewSagaStore returns a SagaStore that reads and writes through pool.
+// pool must point at the database the saga's own steps commit against.
+func NewSagaStore[TRepoRegistry any](pool *pgxpool.Pool) *SagaStore[TRepoRegistry] {
+	return &SagaStore[TRepoRegistry]{pool: pool}
+}
+
+func (s *SagaStore[TRepoRegistry]) Start(ctx context.Context, u uow.UOW[TRepoRegistry], sagaID string, stepNames []string) error {
+	tx, err := txOf(u)
+	if err != nil {
+		return err
+	}
+
+	for i, name := range stepNames {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO uow_sagas (saga_id, step_index, step_name, status) VALUES ($1, $2, $3, $4)
+		`, sagaID, i, name, saga.StepPending); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SagaStore[TRepoRegistry]) SetStepStatus(ctx context.Context, u uow.UOW[TRepoRegistry], sagaID string, stepIndex int, status saga.StepStatus) error {
+	tx, err := txOf(u)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE uow_sagas SET status = $1 WHERE saga_id = $2 AND step_index = $3
+	`, status, sagaID, stepIndex)
+	return err
+}
+
+// InFlight reports, per saga_id, both sagas still running forward
+// (nothing failed yet, not every step committed) and sagas stuck
+// mid-compensation (a step failed and not every step below it has been
+// compensated yet) - a crash can leave either kind behind, and a saga
+// that vanished from this query the moment compensation started would
+// never be finished.
+func (s *SagaStore[TRepoRegistry]) InFlight(ctx context.Context, factory uow.Factory[TRepoRegistry]) (map[string]saga.Recovery, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT
+			saga_id,
+			count(*) FILTER (WHERE status = $1) AS committed,
+			count(*) FILTER (WHERE status = $2) AS compensated,
+			count(*) AS total,
+			min(step_index) FILTER (WHERE status = $3) AS failed_index
+		FROM uow_sagas
+		GROUP BY saga_id
+	`, saga.StepCommitted, saga.StepCompensated, saga.StepFailed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	inFlight := make(map[string]saga.Recovery)
+	for rows.Next() {
+		var sagaID string
+		var committed, compensated, total int
+		var failedIndex *int
+		if err := rows.Scan(&sagaID, &committed, &compensated, &total, &failedIndex); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case failedIndex == nil:
+			if committed < total {
+				inFlight[sagaID] = saga.Recovery{Action: saga.RecoverForward, Step: committed}
+			}
+		case compensated < *failedIndex:
+			inFlight[sagaID] = saga.Recovery{Action: saga.RecoverCompensate, Step: *failedIndex - 1 - compensated}
+		}
+	}
+
+	return inFlight, rows.Err()
+}
+
+func txOf[TRepoRegistry any](u uow.UOW[TRepoRegistry]) (pgx.Tx, error) {
+	txer, ok := u.(Txer)
+	if !ok {
+		return nil, fmt.Errorf("pgxv5: SagaStore requires a pgxv5 UOW, got %T", u)
+	}
+	return txer.Tx(), nil
+}
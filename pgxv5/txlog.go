@@ -0,0 +1,122 @@
+package pgxv5
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/callmemars1/go-uow/twopc"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ twopc.TxLog = &TxLog{}
+
+// TxLog is a Postgres-backed twopc.TxLog. It records the global
+// transaction log in uow_tx_log/uow_tx_log_participants tables in the
+// same database the participants commit against, and cross-checks
+// pg_prepared_xacts before reporting a gid from InDoubt, since the
+// tables alone can't tell a transaction that crashed mid-Prepare from
+// one genuinely left in-doubt after every participant prepared.
+//
+// The schema it expects:
+//
+//	CREATE TABLE uow_tx_log (
+//		gid          text PRIMARY KEY,
+//		participants int  NOT NULL
+//	);
+//	CREATE TABLE uow_tx_log_participants (
+//		gid         text NOT NULL REFERENCES uow_tx_log (gid),
+//		participant int  NOT NULL,
+//		state       text NOT NULL,
+//		PRIMARY KEY (gid, participant)
+//	);
+type TxLog struct {
+	pool *pgxpool.Pool
+}
+
+// NewTxLog returns a TxLog that reads and writes through pool. pool must
+// point at the database the 2PC participants themselves commit against.
+func NewTxLog(pool *pgxpool.Pool) *TxLog {
+	return &TxLog{pool: pool}
+}
+
+func (l *TxLog) Begin(ctx context.Context, gid string, participants int) error {
+	if _, err := l.pool.Exec(ctx, `
+		INSERT INTO uow_tx_log (gid, participants) VALUES ($1, $2)
+	`, gid, participants); err != nil {
+		return err
+	}
+
+	for i := 0; i < participants; i++ {
+		if _, err := l.pool.Exec(ctx, `
+			INSERT INTO uow_tx_log_participants (gid, participant, state) VALUES ($1, $2, $3)
+		`, gid, i, twopc.ParticipantPending); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *TxLog) SetState(ctx context.Context, gid string, participant int, state twopc.ParticipantState) error {
+	_, err := l.pool.Exec(ctx, `
+		INSERT INTO uow_tx_log_participants (gid, participant, state)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (gid, participant) DO UPDATE SET state = EXCLUDED.state
+	`, gid, participant, state)
+	return err
+}
+
+func (l *TxLog) InDoubt(ctx context.Context) ([]string, error) {
+	rows, err := l.pool.Query(ctx, `
+		SELECT t.gid
+		FROM uow_tx_log t
+		WHERE t.participants = (
+			SELECT count(*) FROM uow_tx_log_participants p
+			WHERE p.gid = t.gid AND p.state = $1
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM uow_tx_log_participants p
+			WHERE p.gid = t.gid AND p.state IN ($2, $3)
+		)
+	`, twopc.ParticipantPrepared, twopc.ParticipantCommitted, twopc.ParticipantRolledBack)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []string
+	for rows.Next() {
+		var gid string
+		if err := rows.Scan(&gid); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, gid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	gids := make([]string, 0, len(candidates))
+	for _, gid := range candidates {
+		prepared, err := l.hasPreparedXact(ctx, gid)
+		if err != nil {
+			return nil, fmt.Errorf("twopc: checking pg_prepared_xacts for %s: %w", gid, err)
+		}
+		if prepared {
+			gids = append(gids, gid)
+		}
+	}
+
+	return gids, nil
+}
+
+// hasPreparedXact reports whether gid has a transaction actually
+// prepared on the server, guarding against a crash between SetState
+// recording ParticipantPrepared and the PREPARE TRANSACTION it
+// describes having reached Postgres.
+func (l *TxLog) hasPreparedXact(ctx context.Context, gid string) (bool, error) {
+	var count int
+	err := l.pool.QueryRow(ctx, `SELECT count(*) FROM pg_prepared_xacts WHERE gid = $1`, gid).Scan(&count)
+	return count > 0, err
+}
@@ -3,6 +3,7 @@ package pgxv5
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/callmemars1/go-uow"
 
@@ -11,6 +12,7 @@ import (
 )
 
 var _ uow.UOW[any] = &pgUOW[any]{}
+var _ uow.TwoPhase[any] = &pgUOW[any]{}
 
 type pgUOW[TRepoRegistry any] struct {
 	tx           pgx.Tx
@@ -42,6 +44,13 @@ func (u *pgUOW[TRepoRegistry]) MustRepoRegistry() TRepoRegistry {
 	return u.repoRegistry
 }
 
+// Tx returns the pgx.Tx backing u, so code outside this package (e.g.
+// SagaStore) can run its own bookkeeping statements through the same
+// transaction a step just ran in, without going through repoRegistry.
+func (u *pgUOW[TRepoRegistry]) Tx() pgx.Tx {
+	return u.tx
+}
+
 func (u *pgUOW[TRepoRegistry]) Begin(ctx context.Context, options *sql.TxOptions) error {
 	pgxOptions := mapSQLTxOptionsToPgx(options)
 
@@ -57,8 +66,69 @@ func (u *pgUOW[TRepoRegistry]) Begin(ctx context.Context, options *sql.TxOptions
 	return nil
 }
 
-func (u *pgUOW[TRepoRegistry]) Commit(ctx context.Context) error {
+// BeginNested opens a pgx subtransaction, which pgx implements as a
+// SAVEPOINT under the hood, scoped to the same connection and pool
+// acquisition as u.
+func (u *pgUOW[TRepoRegistry]) BeginNested(ctx context.Context) (uow.UOW[TRepoRegistry], error) {
+	if u.tx == nil {
+		return nil, uow.ErrTransactionNotStarted
+	}
+
+	nestedTx, err := u.tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nested := &pgUOW[TRepoRegistry]{
+		tx:                  nestedTx,
+		repoRegistry:        u.repoRegistryFactory(nestedTx),
+		repoRegistryFactory: u.repoRegistryFactory,
+	}
+
+	return nested, nil
+}
+
+// Prepare stages the transaction as a PostgreSQL prepared transaction
+// instead of committing it. PREPARE TRANSACTION does not accept a bind
+// parameter, so gid is quoted inline after escaping embedded quotes.
+// Once this returns without error, the local transaction branch is
+// already ended; Commit/Rollback must not be called on u afterwards,
+// only CommitPrepared/RollbackPrepared with the same gid.
+func (u *pgUOW[TRepoRegistry]) Prepare(ctx context.Context, gid string) error {
+	if u.tx == nil {
+		return uow.ErrTransactionNotStarted
+	}
+
+	if _, err := u.tx.Exec(ctx, "PREPARE TRANSACTION "+quoteLiteral(gid)); err != nil {
+		return err
+	}
+	u.tx = nil
+
+	return nil
+}
+
+func (u *pgUOW[TRepoRegistry]) CommitPrepared(ctx context.Context, gid string) error {
+	defer u.conn.Release()
+
+	_, err := u.conn.Exec(ctx, "COMMIT PREPARED "+quoteLiteral(gid))
+	return err
+}
+
+func (u *pgUOW[TRepoRegistry]) RollbackPrepared(ctx context.Context, gid string) error {
 	defer u.conn.Release()
+
+	_, err := u.conn.Exec(ctx, "ROLLBACK PREPARED "+quoteLiteral(gid))
+	return err
+}
+
+func quoteLiteral(gid string) string {
+	return "'" + strings.ReplaceAll(gid, "'", "''") + "'"
+}
+
+func (u *pgUOW[TRepoRegistry]) Commit(ctx context.Context) error {
+	if u.conn != nil {
+		defer u.conn.Release()
+	}
 	if u.tx == nil {
 		return uow.ErrTransactionNotStarted
 	}
@@ -67,7 +137,9 @@ func (u *pgUOW[TRepoRegistry]) Commit(ctx context.Context) error {
 }
 
 func (u *pgUOW[TRepoRegistry]) Rollback(ctx context.Context) error {
-	defer u.conn.Release()
+	if u.conn != nil {
+		defer u.conn.Release()
+	}
 	if u.tx == nil {
 		return uow.ErrTransactionNotStarted
 	}
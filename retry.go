@@ -0,0 +1,132 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// sqlStateError is implemented by pgconn.PgError (pgx/v5) and by any
+// database/sql driver error that exposes a SQLState(), letting this
+// package detect retryable Postgres errors without depending on a
+// specific driver.
+type sqlStateError interface {
+	SQLState() string
+}
+
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// DefaultIsRetryable reports whether err is a Postgres serialization
+// failure or deadlock, the two cases where a transaction can be safely
+// retried because the commit never took effect.
+func DefaultIsRetryable(err error) bool {
+	var sse sqlStateError
+	if !errors.As(err, &sse) {
+		return false
+	}
+
+	switch sse.SQLState() {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryPolicy controls how RunTxWithRetry and RunTxWithResultAndRetry
+// re-run an action after a retryable error.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Jitter      bool
+
+	// IsRetryable decides whether a failed attempt should be retried.
+	// DefaultIsRetryable is used when nil.
+	IsRetryable func(error) bool
+}
+
+// DefaultRetryPolicy makes up to 3 attempts in total, backing off
+// exponentially from 50ms up to 1s with jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 50 * time.Millisecond,
+		MaxBackoff:  time.Second,
+		Jitter:      true,
+	}
+}
+
+func (p RetryPolicy) isRetryable(err error) bool {
+	if p.IsRetryable != nil {
+		return p.IsRetryable(err)
+	}
+	return DefaultIsRetryable(err)
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseBackoff << attempt
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter && d > 0 {
+		d = time.Duration(rand.Int63n(int64(d)))
+	}
+	return d
+}
+
+// RunTxWithResultAndRetry runs action via RunTxWithResult, retrying on a
+// retryable error per policy. Every attempt acquires a fresh UOW from
+// factory, since the one from a failed attempt is consumed by Rollback.
+func RunTxWithResultAndRetry[TRepoRegistry any, TReturn any](
+	ctx context.Context,
+	factory Factory[TRepoRegistry],
+	action TxActionWithResult[TRepoRegistry, TReturn],
+	options *sql.TxOptions,
+	policy RetryPolicy,
+	opts ...Option,
+) (res *TReturn, err error) {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		attemptOpts := append(append([]Option{}, opts...), WithAttempt(attempt+1))
+		res, err = RunTxWithResult(ctx, factory, action, options, attemptOpts...)
+		if err == nil || attempt == maxAttempts-1 || !policy.isRetryable(err) {
+			return res, err
+		}
+
+		if d := policy.backoff(attempt); d > 0 {
+			select {
+			case <-time.After(d):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return res, err
+}
+
+// RunTxWithRetry is the error-only counterpart of
+// RunTxWithResultAndRetry, mirroring how RunTx wraps RunTxWithResult.
+func RunTxWithRetry[TRepoRegistry any](
+	ctx context.Context,
+	factory Factory[TRepoRegistry],
+	action TxAction[TRepoRegistry],
+	options *sql.TxOptions,
+	policy RetryPolicy,
+	opts ...Option,
+) error {
+	_, err := RunTxWithResultAndRetry(ctx, factory, func(uow UOW[TRepoRegistry]) (*any, error) {
+		return nil, action(uow)
+	}, options, policy, opts...)
+	return err
+}
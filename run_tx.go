@@ -3,6 +3,7 @@ package uow
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type TxActionWithResult[TRepoRegistry any, TReturn any] func(UOW[TRepoRegistry]) (*TReturn, error)
@@ -12,24 +13,72 @@ func RunTxWithResult[TRepoRegistry any, TReturn any](
 	factory Factory[TRepoRegistry],
 	action TxActionWithResult[TRepoRegistry, TReturn],
 	options *sql.TxOptions,
+	opts ...Option,
 ) (res *TReturn, err error) {
+	cfg := txConfig{attempt: 1}
+	if hp, ok := factory.(hooksProvider); ok {
+		cfg.hooks = hp.GetHooks()
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	hooks := cfg.hooks
+
 	uow, err := factory.NewUOW(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = uow.Begin(ctx, options); err != nil {
+	if hooks.BeforeBegin != nil {
+		if next := hooks.BeforeBegin(ctx, options, cfg.attempt); next != nil {
+			ctx = next
+		}
+	}
+
+	beginStart := time.Now()
+	err = uow.Begin(ctx, options)
+	if hooks.AfterBegin != nil {
+		hooks.AfterBegin(ctx, options, cfg.attempt, time.Since(beginStart), err)
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	rollback := func() {
+		rbCtx := ctx
+		if hooks.BeforeRollback != nil {
+			if next := hooks.BeforeRollback(ctx, options, cfg.attempt); next != nil {
+				rbCtx = next
+			}
+		}
+
+		rbErr := uow.Rollback(rbCtx)
+		if hooks.AfterRollback != nil {
+			hooks.AfterRollback(rbCtx, options, cfg.attempt, time.Since(beginStart), rbErr)
+		}
+	}
+
 	defer func() {
 		if p := recover(); p != nil {
-			_ = uow.Rollback(ctx)
+			if hooks.OnPanic != nil {
+				hooks.OnPanic(ctx, options, cfg.attempt, p)
+			}
+			rollback()
 			panic(p)
 		} else if err != nil {
-			_ = uow.Rollback(ctx)
+			rollback()
 		} else {
-			err = uow.Commit(ctx)
+			commitCtx := ctx
+			if hooks.BeforeCommit != nil {
+				if next := hooks.BeforeCommit(ctx, options, cfg.attempt); next != nil {
+					commitCtx = next
+				}
+			}
+
+			err = uow.Commit(commitCtx)
+			if hooks.AfterCommit != nil {
+				hooks.AfterCommit(commitCtx, options, cfg.attempt, time.Since(beginStart), err)
+			}
 		}
 	}()
 
@@ -44,9 +93,80 @@ func RunTx[TRepoRegistry any](
 	factory Factory[TRepoRegistry],
 	action TxAction[TRepoRegistry],
 	options *sql.TxOptions,
+	opts ...Option,
 ) error {
 	_, err := RunTxWithResult(ctx, factory, func(uow UOW[TRepoRegistry]) (*any, error) {
 		return nil, action(uow)
-	}, options)
+	}, options, opts...)
 	return err
 }
+
+// TxActionCtx is like TxActionWithResult but also receives the ctx carrying
+// the active UOW, so it can be forwarded to further RunTxCtx calls made by
+// the action (e.g. a call into another service method) to compose into the
+// same transactional scope.
+type TxActionCtx[TRepoRegistry any, TReturn any] func(ctx context.Context, uow UOW[TRepoRegistry]) (*TReturn, error)
+
+// RunTxCtx behaves like RunTxWithResult, except it first checks ctx for a
+// UOW placed there by an enclosing RunTxCtx call. If one is found, action
+// runs inside a nested (savepoint) scope of that outer UOW instead of
+// starting a new transaction; only the outermost call owns Begin/Commit/
+// Rollback of the real transaction. The ctx passed to action carries the
+// active UOW so further nested calls compose automatically. Unlike
+// RunTxWithResult, it invokes no Hooks, neither the Factory's nor any
+// others.
+func RunTxCtx[TRepoRegistry any, TReturn any](
+	ctx context.Context,
+	factory Factory[TRepoRegistry],
+	action TxActionCtx[TRepoRegistry, TReturn],
+	options *sql.TxOptions,
+) (res *TReturn, err error) {
+	if outer, ok := FromContext[TRepoRegistry](ctx); ok {
+		var nested UOW[TRepoRegistry]
+		nested, err = outer.BeginNested(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		nestedCtx := NewContext(ctx, nested)
+
+		defer func() {
+			if p := recover(); p != nil {
+				_ = nested.Rollback(ctx)
+				panic(p)
+			} else if err != nil {
+				_ = nested.Rollback(ctx)
+			} else {
+				err = nested.Commit(ctx)
+			}
+		}()
+
+		res, err = action(nestedCtx, nested)
+		return
+	}
+
+	u, err := factory.NewUOW(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.Begin(ctx, options); err != nil {
+		return nil, err
+	}
+
+	rootCtx := NewContext(ctx, u)
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = u.Rollback(ctx)
+			panic(p)
+		} else if err != nil {
+			_ = u.Rollback(ctx)
+		} else {
+			err = u.Commit(ctx)
+		}
+	}()
+
+	res, err = action(rootCtx, u)
+	return
+}
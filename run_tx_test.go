@@ -0,0 +1,93 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+type fakeUOW struct{}
+
+func (fakeUOW) MustRepoRegistry() any { return nil }
+
+func (fakeUOW) Begin(ctx context.Context, options *sql.TxOptions) error { return nil }
+
+func (fakeUOW) BeginNested(ctx context.Context) (UOW[any], error) { return fakeUOW{}, nil }
+
+func (fakeUOW) Commit(ctx context.Context) error { return nil }
+
+func (fakeUOW) Rollback(ctx context.Context) error { return nil }
+
+type fakeFactory struct {
+	hooks Hooks
+}
+
+func (f fakeFactory) NewUOW(ctx context.Context) (UOW[any], error) { return fakeUOW{}, nil }
+
+func (f fakeFactory) Release() error { return nil }
+
+func (f fakeFactory) GetHooks() Hooks { return f.hooks }
+
+// TestRunTx_OnPanicFiresBeforeAfterRollback guards against OnPanic
+// running after rollback's AfterRollback hook already ended an
+// instrumentation span: if AfterRollback fires first, a span status of
+// Ok and zero panic events get recorded, and OnPanic's own span calls
+// become no-ops against the already-ended span.
+func TestRunTx_OnPanicFiresBeforeAfterRollback(t *testing.T) {
+	var order []string
+
+	factory := fakeFactory{hooks: Hooks{
+		AfterRollback: func(ctx context.Context, options *sql.TxOptions, attempt int, duration time.Duration, err error) {
+			order = append(order, "after_rollback")
+		},
+		OnPanic: func(ctx context.Context, options *sql.TxOptions, attempt int, recovered any) {
+			order = append(order, "on_panic")
+		},
+	}}
+
+	func() {
+		defer func() { _ = recover() }()
+		_ = RunTx[any](context.Background(), factory, func(UOW[any]) error {
+			panic("boom")
+		}, DefaultTxOptions())
+	}()
+
+	want := []string{"on_panic", "after_rollback"}
+	if len(order) != len(want) {
+		t.Fatalf("hook order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("hook order = %v, want %v", order, want)
+		}
+	}
+}
+
+// TestRunTx_AfterCommitDurationCoversBegin guards against the duration
+// passed to AfterCommit/AfterRollback measuring only the Commit/Rollback
+// call itself: it must cover the whole transaction, from Begin onward,
+// to match what a "transaction duration" metric is documented to report.
+func TestRunTx_AfterCommitDurationCoversBegin(t *testing.T) {
+	const sleep = 20 * time.Millisecond
+
+	var gotDuration time.Duration
+
+	factory := fakeFactory{hooks: Hooks{
+		AfterCommit: func(ctx context.Context, options *sql.TxOptions, attempt int, duration time.Duration, err error) {
+			gotDuration = duration
+		},
+	}}
+
+	err := RunTx[any](context.Background(), factory, func(UOW[any]) error {
+		time.Sleep(sleep)
+		return nil
+	}, DefaultTxOptions())
+	if err != nil {
+		t.Fatalf("RunTx returned an error: %v", err)
+	}
+
+	if gotDuration < sleep {
+		t.Fatalf("AfterCommit duration = %v, want at least %v (the action's own sleep)", gotDuration, sleep)
+	}
+}
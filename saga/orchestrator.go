@@ -0,0 +1,182 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/callmemars1/go-uow"
+)
+
+// Orchestrator runs a fixed sequence of Steps as a saga: each Do runs in
+// its own transaction and, on failure, every already-committed step is
+// undone in reverse order by running its Compensate in its own fresh
+// transaction. Set RetryPolicy to retry an individual step's transaction
+// on a serialization failure or deadlock before the saga gives up and
+// starts compensating.
+type Orchestrator[TRepoRegistry any] struct {
+	Factory     uow.Factory[TRepoRegistry]
+	Steps       []Step[TRepoRegistry]
+	Store       SagaStore[TRepoRegistry]
+	RetryPolicy *uow.RetryPolicy
+}
+
+func NewOrchestrator[TRepoRegistry any](
+	factory uow.Factory[TRepoRegistry],
+	steps []Step[TRepoRegistry],
+	store SagaStore[TRepoRegistry],
+) *Orchestrator[TRepoRegistry] {
+	return &Orchestrator[TRepoRegistry]{
+		Factory: factory,
+		Steps:   steps,
+		Store:   store,
+	}
+}
+
+// Run executes the saga identified by sagaID from its first step.
+func (o *Orchestrator[TRepoRegistry]) Run(ctx context.Context, sagaID string) error {
+	if o.Store != nil {
+		names := make([]string, len(o.Steps))
+		for i, step := range o.Steps {
+			names[i] = step.Name
+		}
+
+		if err := o.runStart(ctx, sagaID, names); err != nil {
+			return err
+		}
+	}
+
+	return o.runFrom(ctx, sagaID, 0)
+}
+
+// Recover resumes every saga Store reports as in-flight: forward from
+// the step after the last one committed, or compensating downward from
+// the step after the last one compensated, per each saga's Recovery. A
+// crash between a step's commit/compensation and recording that in
+// Store at worst re-runs or re-compensates that one step. Every saga is
+// resumed even if an earlier one fails; the first error is returned.
+func (o *Orchestrator[TRepoRegistry]) Recover(ctx context.Context) error {
+	if o.Store == nil {
+		return nil
+	}
+
+	inFlight, err := o.Store.InFlight(ctx, o.Factory)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for sagaID, recovery := range inFlight {
+		var err error
+		switch recovery.Action {
+		case RecoverCompensate:
+			err = o.compensate(ctx, sagaID, recovery.Step+1, fmt.Errorf("saga: resuming a compensation interrupted by a crash"))
+		default:
+			err = o.runFrom(ctx, sagaID, recovery.Step)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (o *Orchestrator[TRepoRegistry]) runFrom(ctx context.Context, sagaID string, from int) error {
+	committed := from
+
+	for i := from; i < len(o.Steps); i++ {
+		step := o.Steps[i]
+
+		if err := o.runStep(ctx, sagaID, i, step.Do, StepCommitted); err != nil {
+			o.markFailed(ctx, sagaID, i)
+			return o.compensate(ctx, sagaID, committed, fmt.Errorf("saga: step %q failed: %w", step.Name, err))
+		}
+
+		committed = i + 1
+	}
+
+	return nil
+}
+
+func (o *Orchestrator[TRepoRegistry]) compensate(ctx context.Context, sagaID string, upTo int, cause error) error {
+	for i := upTo - 1; i >= 0; i-- {
+		step := o.Steps[i]
+		if step.Compensate == nil {
+			// Record it as compensated anyway, in its own best-effort
+			// transaction: Recover's resume point is computed from how
+			// many steps below the failure are marked compensated, so a
+			// skipped step must still advance that count or recovery
+			// would get stuck retrying it forever.
+			o.markCompensated(ctx, sagaID, i)
+			continue
+		}
+
+		if err := o.runStep(ctx, sagaID, i, step.Compensate, StepCompensated); err != nil {
+			return fmt.Errorf("%w (compensating step %q also failed: %v)", cause, step.Name, err)
+		}
+	}
+
+	return cause
+}
+
+// markFailed records a step's failure in its own transaction, best
+// effort: the step's own transaction already rolled back, so this
+// cannot share its atomicity, and a failure here doesn't stop
+// compensation from proceeding.
+func (o *Orchestrator[TRepoRegistry]) markFailed(ctx context.Context, sagaID string, stepIndex int) {
+	if o.Store == nil {
+		return
+	}
+
+	_ = uow.RunTx(ctx, o.Factory, func(u uow.UOW[TRepoRegistry]) error {
+		return o.Store.SetStepStatus(ctx, u, sagaID, stepIndex, StepFailed)
+	}, uow.DefaultTxOptions())
+}
+
+// markCompensated records a nil-Compensate step as compensated in its
+// own transaction, best effort, the same way markFailed records a
+// failure: there's no step transaction of its own to share atomicity
+// with here either.
+func (o *Orchestrator[TRepoRegistry]) markCompensated(ctx context.Context, sagaID string, stepIndex int) {
+	if o.Store == nil {
+		return
+	}
+
+	_ = uow.RunTx(ctx, o.Factory, func(u uow.UOW[TRepoRegistry]) error {
+		return o.Store.SetStepStatus(ctx, u, sagaID, stepIndex, StepCompensated)
+	}, uow.DefaultTxOptions())
+}
+
+// runStart records sagaID in Store in its own transaction.
+func (o *Orchestrator[TRepoRegistry]) runStart(ctx context.Context, sagaID string, stepNames []string) error {
+	return uow.RunTx(ctx, o.Factory, func(u uow.UOW[TRepoRegistry]) error {
+		return o.Store.Start(ctx, u, sagaID, stepNames)
+	}, uow.DefaultTxOptions())
+}
+
+// runStep runs fn and, if it succeeds, records status in the same
+// transaction so the step's effects and its bookkeeping commit or roll
+// back together.
+func (o *Orchestrator[TRepoRegistry]) runStep(
+	ctx context.Context,
+	sagaID string,
+	stepIndex int,
+	fn func(uow.UOW[TRepoRegistry]) error,
+	status StepStatus,
+) error {
+	action := func(u uow.UOW[TRepoRegistry]) error {
+		if err := fn(u); err != nil {
+			return err
+		}
+		if o.Store == nil {
+			return nil
+		}
+		return o.Store.SetStepStatus(ctx, u, sagaID, stepIndex, status)
+	}
+
+	if o.RetryPolicy != nil {
+		return uow.RunTxWithRetry(ctx, o.Factory, action, uow.DefaultTxOptions(), *o.RetryPolicy)
+	}
+
+	return uow.RunTx(ctx, o.Factory, action, uow.DefaultTxOptions())
+}
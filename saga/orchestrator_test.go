@@ -0,0 +1,116 @@
+package saga
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/callmemars1/go-uow"
+)
+
+type fakeRegistry struct{}
+
+type fakeUOW struct{}
+
+func (fakeUOW) MustRepoRegistry() fakeRegistry { return fakeRegistry{} }
+
+func (fakeUOW) Begin(ctx context.Context, options *sql.TxOptions) error { return nil }
+
+func (fakeUOW) BeginNested(ctx context.Context) (uow.UOW[fakeRegistry], error) {
+	return fakeUOW{}, nil
+}
+
+func (fakeUOW) Commit(ctx context.Context) error { return nil }
+
+func (fakeUOW) Rollback(ctx context.Context) error { return nil }
+
+type fakeFactory struct{}
+
+func (fakeFactory) NewUOW(ctx context.Context) (uow.UOW[fakeRegistry], error) {
+	return fakeUOW{}, nil
+}
+
+func (fakeFactory) Release() error { return nil }
+
+// fakeStore reports a fixed Recovery from InFlight and otherwise does
+// nothing; Start and SetStepStatus accept every call and record nothing.
+type fakeStore struct {
+	recovery Recovery
+}
+
+func (s *fakeStore) Start(ctx context.Context, u uow.UOW[fakeRegistry], sagaID string, stepNames []string) error {
+	return nil
+}
+
+func (s *fakeStore) SetStepStatus(ctx context.Context, u uow.UOW[fakeRegistry], sagaID string, stepIndex int, status StepStatus) error {
+	return nil
+}
+
+func (s *fakeStore) InFlight(ctx context.Context, factory uow.Factory[fakeRegistry]) (map[string]Recovery, error) {
+	return map[string]Recovery{"saga-1": s.recovery}, nil
+}
+
+// TestOrchestrator_Recover_DoesNotRerunCommittedSteps guards against the
+// off-by-one where runFrom treats its `from` argument as the next step
+// to run: if InFlight reported the index of the last *committed* step
+// instead of the next one to run, that step would execute a second time
+// here.
+func TestOrchestrator_Recover_DoesNotRerunCommittedSteps(t *testing.T) {
+	var ran []string
+
+	steps := []Step[fakeRegistry]{
+		{Name: "step0", Do: func(uow.UOW[fakeRegistry]) error { ran = append(ran, "step0"); return nil }},
+		{Name: "step1", Do: func(uow.UOW[fakeRegistry]) error { ran = append(ran, "step1"); return nil }},
+		{Name: "step2", Do: func(uow.UOW[fakeRegistry]) error { ran = append(ran, "step2"); return nil }},
+	}
+
+	o := NewOrchestrator[fakeRegistry](fakeFactory{}, steps, &fakeStore{recovery: Recovery{Action: RecoverForward, Step: 1}})
+
+	if err := o.Recover(context.Background()); err != nil {
+		t.Fatalf("Recover returned an error: %v", err)
+	}
+
+	want := []string{"step1", "step2"}
+	if len(ran) != len(want) {
+		t.Fatalf("ran steps %v, want %v", ran, want)
+	}
+	for i := range want {
+		if ran[i] != want[i] {
+			t.Fatalf("ran steps %v, want %v", ran, want)
+		}
+	}
+}
+
+// TestOrchestrator_Recover_ResumesInterruptedCompensation guards against
+// a saga crashing mid-compensation being stuck forever: InFlight
+// reporting RecoverCompensate must make Recover compensate the
+// remaining steps downward from Step, not just resume running forward.
+func TestOrchestrator_Recover_ResumesInterruptedCompensation(t *testing.T) {
+	var compensated []string
+
+	steps := []Step[fakeRegistry]{
+		{Name: "step0", Do: noopStep, Compensate: func(uow.UOW[fakeRegistry]) error { compensated = append(compensated, "step0"); return nil }},
+		{Name: "step1", Do: noopStep, Compensate: func(uow.UOW[fakeRegistry]) error { compensated = append(compensated, "step1"); return nil }},
+		{Name: "step2", Do: noopStep, Compensate: func(uow.UOW[fakeRegistry]) error { compensated = append(compensated, "step2"); return nil }},
+	}
+
+	// step2 failed, step1 was already compensated before the crash; only
+	// step0 should still need compensating.
+	o := NewOrchestrator[fakeRegistry](fakeFactory{}, steps, &fakeStore{recovery: Recovery{Action: RecoverCompensate, Step: 0}})
+
+	if err := o.Recover(context.Background()); err == nil {
+		t.Fatal("expected Recover to surface the original saga failure as an error")
+	}
+
+	want := []string{"step0"}
+	if len(compensated) != len(want) {
+		t.Fatalf("compensated steps %v, want %v", compensated, want)
+	}
+	for i := range want {
+		if compensated[i] != want[i] {
+			t.Fatalf("compensated steps %v, want %v", compensated, want)
+		}
+	}
+}
+
+func noopStep(uow.UOW[fakeRegistry]) error { return nil }
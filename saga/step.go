@@ -0,0 +1,23 @@
+package saga
+
+import "github.com/callmemars1/go-uow"
+
+// StepStatus is the per-step status a SagaStore persists for a saga.
+type StepStatus string
+
+const (
+	StepPending     StepStatus = "pending"
+	StepCommitted   StepStatus = "committed"
+	StepCompensated StepStatus = "compensated"
+	StepFailed      StepStatus = "failed"
+)
+
+// Step is one local transactional unit of a saga. Do runs in its own
+// transaction; if a later step fails, Compensate runs in its own fresh
+// transaction to undo Do's effects. A Step with a nil Compensate is
+// treated as non-reversible and simply skipped during compensation.
+type Step[TRepoRegistry any] struct {
+	Name       string
+	Do         func(uow.UOW[TRepoRegistry]) error
+	Compensate func(uow.UOW[TRepoRegistry]) error
+}
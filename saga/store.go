@@ -0,0 +1,53 @@
+package saga
+
+import (
+	"context"
+
+	"github.com/callmemars1/go-uow"
+)
+
+// SagaStore persists saga progress (saga ID, current step index, per-
+// step status) so Orchestrator.Recover can resume or compensate a saga
+// left in-flight by a crash. When recording a step's commit or
+// compensation, SetStepStatus receives the same UOW that step just ran
+// in (a failure, or a step with a nil Compensate, is recorded in a fresh
+// UOW of its own), so a concrete implementation can write its bookkeeping
+// row through that UOW's repo registry (e.g. a SagaRepo backed by a
+// uow_sagas table) and have it commit or roll back atomically with the
+// step's own effects. See pgxv5.SagaStore for a Postgres-backed
+// implementation that writes directly through the UOW's pgx.Tx instead.
+type SagaStore[TRepoRegistry any] interface {
+	// Start records a new saga with the given step names, all starting
+	// StepPending.
+	Start(ctx context.Context, u uow.UOW[TRepoRegistry], sagaID string, stepNames []string) error
+
+	SetStepStatus(ctx context.Context, u uow.UOW[TRepoRegistry], sagaID string, stepIndex int, status StepStatus) error
+
+	// InFlight returns, for every saga that hasn't reached a terminal
+	// state (all steps committed with none failed, or compensation run
+	// all the way down to step 0), its ID and where Orchestrator.Recover
+	// should resume it: forward from the step after the last one
+	// recorded as committed, or compensating downward from the step
+	// after the last one recorded as compensated, whichever applies to
+	// that saga.
+	InFlight(ctx context.Context, factory uow.Factory[TRepoRegistry]) (map[string]Recovery, error)
+}
+
+// RecoveryAction is the direction Orchestrator.Recover should resume an
+// in-flight saga in, as reported by SagaStore.InFlight.
+type RecoveryAction int
+
+const (
+	// RecoverForward resumes running Step.Do forward from Recovery.Step.
+	RecoverForward RecoveryAction = iota
+	// RecoverCompensate resumes running Step.Compensate backward from
+	// Recovery.Step.
+	RecoverCompensate
+)
+
+// Recovery is the resume point SagaStore.InFlight reports for one
+// in-flight saga.
+type Recovery struct {
+	Action RecoveryAction
+	Step   int
+}
@@ -15,6 +15,11 @@ type Factory[TRepoRegistry any] struct {
 	db *sql.DB
 
 	repoRegistryFactory RepoRegistryFactory[TRepoRegistry]
+
+	// Hooks, when set, are used as the default uow.Hooks for every
+	// RunTxWithResult, RunTx and retry-helper call made with this
+	// Factory. RunTxCtx does not invoke hooks.
+	Hooks uow.Hooks
 }
 
 func NewFactory[TRepoRegistry any](
@@ -36,3 +41,7 @@ func (f *Factory[TRepoRegistry]) NewUOW(ctx context.Context) (uow.UOW[TRepoRegis
 func (f *Factory[TRepoRegistry]) Release() error {
 	return f.db.Close()
 }
+
+func (f *Factory[TRepoRegistry]) GetHooks() uow.Hooks {
+	return f.Hooks
+}
@@ -16,6 +16,13 @@ type sqlUOW[TRepoRegistry any] struct {
 
 	db                  *sql.DB
 	repoRegistryFactory RepoRegistryFactory[TRepoRegistry]
+
+	// savepointName is non-empty for a UOW created by BeginNested, in
+	// which case Commit/Rollback operate on the savepoint instead of the
+	// underlying *sql.Tx. savepoints is shared with the root UOW so
+	// nested scopes at any depth get a unique sp_<N> name.
+	savepointName string
+	savepoints    *int
 }
 
 func newSQLUOW[TRepoRegistry any](db *sql.DB, repoRegistryFactory RepoRegistryFactory[TRepoRegistry]) *sqlUOW[TRepoRegistry] {
@@ -39,17 +46,52 @@ func (u *sqlUOW[TRepoRegistry]) Begin(ctx context.Context, options *sql.TxOption
 	}
 	u.tx = tx
 
+	savepoints := 0
+	u.savepoints = &savepoints
+
 	repoRegistry := u.repoRegistryFactory(tx)
 	u.repoRegistry = repoRegistry
 
 	return nil
 }
 
+// BeginNested issues a SAVEPOINT against the same *sql.Tx and returns a
+// UOW whose Commit/Rollback release or roll back to that savepoint
+// instead of ending the underlying transaction.
+func (u *sqlUOW[TRepoRegistry]) BeginNested(ctx context.Context) (uow.UOW[TRepoRegistry], error) {
+	if u.tx == nil {
+		return nil, uow.ErrTransactionNotStarted
+	}
+
+	*u.savepoints = *u.savepoints + 1
+	name := fmt.Sprintf("sp_%d", *u.savepoints)
+
+	if _, err := u.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	nested := &sqlUOW[TRepoRegistry]{
+		tx:                  u.tx,
+		repoRegistry:        u.repoRegistryFactory(u.tx),
+		db:                  u.db,
+		repoRegistryFactory: u.repoRegistryFactory,
+		savepointName:       name,
+		savepoints:          u.savepoints,
+	}
+
+	return nested, nil
+}
+
 func (u *sqlUOW[TRepoRegistry]) Commit(ctx context.Context) error {
 	if u.tx == nil {
 		return uow.ErrTransactionNotStarted
 	}
 
+	if u.savepointName != "" {
+		_, err := u.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+u.savepointName)
+		return err
+	}
+
 	return u.tx.Commit()
 }
 
@@ -58,5 +100,10 @@ func (u *sqlUOW[TRepoRegistry]) Rollback(ctx context.Context) error {
 		return uow.ErrTransactionNotStarted
 	}
 
+	if u.savepointName != "" {
+		_, err := u.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+u.savepointName)
+		return err
+	}
+
 	return u.tx.Rollback()
 }
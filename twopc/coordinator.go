@@ -0,0 +1,146 @@
+package twopc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/callmemars1/go-uow"
+)
+
+// ErrNotTwoPhase is returned when a Factory's UOW does not implement
+// uow.TwoPhase, so it cannot take part in a Coordinator.
+var ErrNotTwoPhase = errors.New("twopc: uow does not implement uow.TwoPhase")
+
+// Coordinator drives a two-phase commit across N participants that share
+// the same TRepoRegistry shape, e.g. N shards of one schema. action runs
+// once per participant; only once every participant has run it without
+// error does Coordinator Prepare and then CommitPrepared all of them, so
+// the group ends up either fully committed or fully rolled back.
+type Coordinator[TRepoRegistry any] struct {
+	Factories []uow.Factory[TRepoRegistry]
+	TxLog     TxLog
+}
+
+func NewCoordinator[TRepoRegistry any](factories []uow.Factory[TRepoRegistry], txLog TxLog) *Coordinator[TRepoRegistry] {
+	return &Coordinator[TRepoRegistry]{
+		Factories: factories,
+		TxLog:     txLog,
+	}
+}
+
+// Run executes action against a fresh UOW from every factory, then
+// prepares and commits all of them as one atomic group identified by
+// gid. gid must be unique per call; Recover uses it to finish groups
+// left in-doubt by a crash between Prepare and CommitPrepared.
+func (c *Coordinator[TRepoRegistry]) Run(ctx context.Context, gid string, action uow.TxAction[TRepoRegistry]) error {
+	branches := make([]uow.TwoPhase[TRepoRegistry], 0, len(c.Factories))
+
+	rollbackAll := func() {
+		for _, b := range branches {
+			_ = b.Rollback(ctx)
+		}
+	}
+
+	for _, factory := range c.Factories {
+		u, err := factory.NewUOW(ctx)
+		if err != nil {
+			rollbackAll()
+			return err
+		}
+
+		branch, ok := u.(uow.TwoPhase[TRepoRegistry])
+		if !ok {
+			rollbackAll()
+			return ErrNotTwoPhase
+		}
+
+		if err := branch.Begin(ctx, uow.DefaultTxOptions()); err != nil {
+			rollbackAll()
+			return err
+		}
+		branches = append(branches, branch)
+
+		if err := action(branch); err != nil {
+			rollbackAll()
+			return err
+		}
+	}
+
+	if c.TxLog != nil {
+		if err := c.TxLog.Begin(ctx, gid, len(branches)); err != nil {
+			rollbackAll()
+			return err
+		}
+	}
+
+	for i, branch := range branches {
+		if err := branch.Prepare(ctx, gid); err != nil {
+			for j := 0; j < i; j++ {
+				_ = branches[j].RollbackPrepared(ctx, gid)
+			}
+			_ = branch.Rollback(ctx)
+			for j := i + 1; j < len(branches); j++ {
+				_ = branches[j].Rollback(ctx)
+			}
+			return fmt.Errorf("twopc: prepare failed for participant %d: %w", i, err)
+		}
+		c.setState(ctx, gid, i, ParticipantPrepared)
+	}
+
+	for i, branch := range branches {
+		if err := branch.CommitPrepared(ctx, gid); err != nil {
+			// The group is now in-doubt: earlier participants may already
+			// be committed. Recover reconciles this from TxLog rather
+			// than retrying blindly here.
+			return fmt.Errorf("twopc: commit failed for participant %d, transaction is in-doubt: %w", i, err)
+		}
+		c.setState(ctx, gid, i, ParticipantCommitted)
+	}
+
+	return nil
+}
+
+// Recover finishes global transactions left in-doubt by a crash between
+// Prepare and CommitPrepared: for every gid TxLog reports via InDoubt, it
+// calls CommitPrepared on every participant. It assumes Prepare already
+// succeeded on all participants for that gid, which is what InDoubt is
+// defined to report.
+func (c *Coordinator[TRepoRegistry]) Recover(ctx context.Context) error {
+	if c.TxLog == nil {
+		return nil
+	}
+
+	gids, err := c.TxLog.InDoubt(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, gid := range gids {
+		for i, factory := range c.Factories {
+			u, err := factory.NewUOW(ctx)
+			if err != nil {
+				return err
+			}
+
+			branch, ok := u.(uow.TwoPhase[TRepoRegistry])
+			if !ok {
+				return ErrNotTwoPhase
+			}
+
+			if err := branch.CommitPrepared(ctx, gid); err != nil {
+				return fmt.Errorf("twopc: recovering %s participant %d: %w", gid, i, err)
+			}
+			c.setState(ctx, gid, i, ParticipantCommitted)
+		}
+	}
+
+	return nil
+}
+
+func (c *Coordinator[TRepoRegistry]) setState(ctx context.Context, gid string, participant int, state ParticipantState) {
+	if c.TxLog == nil {
+		return
+	}
+	_ = c.TxLog.SetState(ctx, gid, participant, state)
+}
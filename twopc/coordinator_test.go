@@ -0,0 +1,92 @@
+package twopc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/callmemars1/go-uow"
+)
+
+type fakeRegistry struct{}
+
+type fakeBranch struct {
+	failPrepare bool
+
+	rolledBack       bool
+	rollbackPrepared bool
+}
+
+func (b *fakeBranch) MustRepoRegistry() fakeRegistry { return fakeRegistry{} }
+
+func (b *fakeBranch) Begin(ctx context.Context, options *sql.TxOptions) error { return nil }
+
+func (b *fakeBranch) BeginNested(ctx context.Context) (uow.UOW[fakeRegistry], error) {
+	return nil, errors.New("fakeBranch: nested transactions not supported")
+}
+
+func (b *fakeBranch) Commit(ctx context.Context) error { return nil }
+
+func (b *fakeBranch) Rollback(ctx context.Context) error {
+	b.rolledBack = true
+	return nil
+}
+
+func (b *fakeBranch) Prepare(ctx context.Context, gid string) error {
+	if b.failPrepare {
+		return errors.New("prepare failed")
+	}
+	return nil
+}
+
+func (b *fakeBranch) CommitPrepared(ctx context.Context, gid string) error { return nil }
+
+func (b *fakeBranch) RollbackPrepared(ctx context.Context, gid string) error {
+	b.rollbackPrepared = true
+	return nil
+}
+
+type fakeFactory struct {
+	branch *fakeBranch
+}
+
+func (f *fakeFactory) NewUOW(ctx context.Context) (uow.UOW[fakeRegistry], error) {
+	return f.branch, nil
+}
+
+func (f *fakeFactory) Release() error { return nil }
+
+// TestCoordinator_Run_RollsBackEveryParticipantOnPrepareFailure guards
+// against the branch whose own Prepare fails being left un-rolled-back:
+// since pgUOW.Prepare only clears its tx on success, skipping it here
+// would leak that branch's connection and leave its local transaction
+// open.
+func TestCoordinator_Run_RollsBackEveryParticipantOnPrepareFailure(t *testing.T) {
+	before := &fakeBranch{}
+	failing := &fakeBranch{failPrepare: true}
+	after := &fakeBranch{}
+
+	factories := []uow.Factory[fakeRegistry]{
+		&fakeFactory{branch: before},
+		&fakeFactory{branch: failing},
+		&fakeFactory{branch: after},
+	}
+
+	c := NewCoordinator[fakeRegistry](factories, nil)
+
+	err := c.Run(context.Background(), "gid-1", func(uow.UOW[fakeRegistry]) error { return nil })
+	if err == nil {
+		t.Fatal("expected an error from a failed Prepare")
+	}
+
+	if !before.rollbackPrepared {
+		t.Error("expected the already-prepared participant to be rolled back via RollbackPrepared")
+	}
+	if !failing.rolledBack {
+		t.Error("expected the participant whose own Prepare failed to be rolled back too")
+	}
+	if !after.rolledBack {
+		t.Error("expected the not-yet-prepared participant to be rolled back")
+	}
+}
@@ -0,0 +1,36 @@
+package twopc
+
+import "context"
+
+// ParticipantState is the status of one participant within a global
+// transaction, as tracked by a TxLog.
+type ParticipantState string
+
+const (
+	ParticipantPending    ParticipantState = "pending"
+	ParticipantPrepared   ParticipantState = "prepared"
+	ParticipantCommitted  ParticipantState = "committed"
+	ParticipantRolledBack ParticipantState = "rolled_back"
+)
+
+// TxLog persists the global transaction log (gid -> participant count ->
+// per-participant state) a global transaction needs to survive a crash
+// between Prepare and CommitPrepared. Coordinator.Recover uses InDoubt to
+// find gids to finish on restart. See pgxv5.TxLog for a Postgres-backed
+// implementation.
+type TxLog interface {
+	// Begin records a new global transaction identified by gid with the
+	// given number of participants, all starting ParticipantPending.
+	Begin(ctx context.Context, gid string, participants int) error
+
+	SetState(ctx context.Context, gid string, participant int, state ParticipantState) error
+
+	// InDoubt returns the gids of every global transaction whose
+	// participants all reached ParticipantPrepared but not all reached
+	// ParticipantCommitted/ParticipantRolledBack - i.e. transactions
+	// left in-doubt by a crash. A Postgres-backed TxLog should cross-
+	// check its own bookkeeping against pg_prepared_xacts on each
+	// participant before reporting a gid here, in case the crash
+	// happened before Prepare actually reached the server.
+	InDoubt(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,20 @@
+package uow
+
+import "context"
+
+// TwoPhase is an optional capability a UOW adapter may implement to
+// support PostgreSQL-style prepared (2PC) transactions. Callers type-
+// assert a UOW returned by a Factory to TwoPhase[TRepoRegistry] to use
+// it; see uow/twopc for a Coordinator that drives Prepare/CommitPrepared
+// across several participants as one atomic group.
+type TwoPhase[TRepoRegistry any] interface {
+	UOW[TRepoRegistry]
+
+	// Prepare stages the transaction under gid instead of ending it, so
+	// a later CommitPrepared/RollbackPrepared - potentially from another
+	// process, after a crash - can still finish it. It must be called
+	// after the action has run and in place of Commit.
+	Prepare(ctx context.Context, gid string) error
+	CommitPrepared(ctx context.Context, gid string) error
+	RollbackPrepared(ctx context.Context, gid string) error
+}
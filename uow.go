@@ -8,6 +8,10 @@ import (
 type UOW[TRepoRegistry any] interface {
 	MustRepoRegistry() TRepoRegistry
 	Begin(ctx context.Context, options *sql.TxOptions) error
+	// BeginNested opens a savepoint-backed UOW scoped to this one. The
+	// returned UOW's Commit/Rollback only affect the savepoint; the
+	// outer transaction is left for the caller that opened it.
+	BeginNested(ctx context.Context) (UOW[TRepoRegistry], error)
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
 }
@@ -16,3 +20,19 @@ type Factory[TRepoRegistry any] interface {
 	NewUOW(ctx context.Context) (UOW[TRepoRegistry], error)
 	Release() error
 }
+
+// txKey is instantiated per TRepoRegistry so unrelated UOW types never
+// collide in a shared context.Context.
+type txKey[TRepoRegistry any] struct{}
+
+// FromContext returns the UOW stored by NewContext, if any.
+func FromContext[TRepoRegistry any](ctx context.Context) (UOW[TRepoRegistry], bool) {
+	u, ok := ctx.Value(txKey[TRepoRegistry]{}).(UOW[TRepoRegistry])
+	return u, ok
+}
+
+// NewContext returns a copy of ctx carrying uow, so a nested RunTxCtx
+// call can find and reuse it.
+func NewContext[TRepoRegistry any](ctx context.Context, uow UOW[TRepoRegistry]) context.Context {
+	return context.WithValue(ctx, txKey[TRepoRegistry]{}, uow)
+}